@@ -0,0 +1,257 @@
+// Package check implements a small static-analysis helper that
+// validates the names and signatures of Go test functions
+// (TestXxx, BenchmarkXxx, ExampleXxx, FuzzXxx and TestMain) the same
+// way the stdlib `go test` command does, so that code depending on
+// [github.com/goaux/istest]'s conventions can be enforced
+// programmatically rather than only at test-run time.
+package check
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Issue describes a single problem found in a test file.
+type Issue struct {
+	Pos      token.Position
+	Category string
+	Message  string
+}
+
+// String returns a human-readable representation of the issue.
+func (i Issue) String() string {
+	return fmt.Sprintf("%s: %s: %s", i.Pos, i.Category, i.Message)
+}
+
+// CheckDir parses every "_test.go" file directly inside dir and returns
+// the issues found across them.
+func CheckDir(dir string) ([]Issue, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(dir, entry.Name()), nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	return CheckFiles(fset, files), nil
+}
+
+// CheckFiles walks the top-level function declarations in files and
+// reports malformed test function names and signatures.
+func CheckFiles(fset *token.FileSet, files []*ast.File) []Issue {
+	var issues []Issue
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv != nil {
+				continue
+			}
+			issues = append(issues, checkFunc(fset, fn)...)
+		}
+	}
+	return issues
+}
+
+// testPrefixes lists the prefixes, other than the exact name
+// "TestMain", that mark a top-level function as a test function.
+var testPrefixes = []string{"Benchmark", "Example", "Fuzz", "Test"}
+
+// category returns the test function category name matches, or "" if
+// name does not look like a test function at all. "TestMain" is only
+// ever matched by its exact name: a function like "TestMainHelper" is
+// a regular Test function, exactly as `go test` treats it.
+func category(name string) string {
+	if name == "TestMain" {
+		return "TestMain"
+	}
+	for _, prefix := range testPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return prefix
+		}
+	}
+	return ""
+}
+
+// hasValidName reports whether name is well-formed for the given
+// category: after stripping the prefix, the first rune (if any) must
+// not be lowercase, mirroring the rule `go test` itself applies when
+// deciding whether TestFoo/Testfoo/etc. are really test functions.
+func hasValidName(name, category string) bool {
+	if category == "TestMain" {
+		return true
+	}
+	rest := strings.TrimPrefix(name, category)
+	if rest == "" {
+		return true
+	}
+	r, _ := utf8.DecodeRuneInString(rest)
+	return !unicode.IsLower(r)
+}
+
+func checkFunc(fset *token.FileSet, fn *ast.FuncDecl) []Issue {
+	name := fn.Name.Name
+	cat := category(name)
+	if cat == "" {
+		return nil
+	}
+	pos := fset.Position(fn.Pos())
+	if !hasValidName(name, cat) {
+		return []Issue{{
+			Pos:      pos,
+			Category: cat,
+			Message:  fmt.Sprintf("%s has malformed name: letter following %q must not be lowercase", name, cat),
+		}}
+	}
+
+	var issues []Issue
+	switch cat {
+	case "TestMain":
+		if !hasSignature(fn, "*testing.M") {
+			issues = append(issues, signatureIssue(pos, cat, name, "func(m *testing.M)"))
+		}
+	case "Test":
+		if !hasSignature(fn, "*testing.T") {
+			issues = append(issues, signatureIssue(pos, cat, name, "func(t *testing.T)"))
+		}
+	case "Benchmark":
+		if !hasSignature(fn, "*testing.B") {
+			issues = append(issues, signatureIssue(pos, cat, name, "func(b *testing.B)"))
+		}
+	case "Fuzz":
+		if !hasSignature(fn, "*testing.F") {
+			issues = append(issues, signatureIssue(pos, cat, name, "func(f *testing.F)"))
+		}
+		issues = append(issues, checkFuzzCallbacks(fset, fn)...)
+	case "Example":
+		if !isNiladic(fn) {
+			issues = append(issues, Issue{
+				Pos:      pos,
+				Category: cat,
+				Message:  fmt.Sprintf("%s must have signature func()", name),
+			})
+		}
+	}
+	return issues
+}
+
+func signatureIssue(pos token.Position, category, name, want string) Issue {
+	return Issue{
+		Pos:      pos,
+		Category: category,
+		Message:  fmt.Sprintf("%s has wrong signature, want %s", name, want),
+	}
+}
+
+// hasSignature reports whether fn takes exactly one parameter of type
+// want and returns nothing.
+func hasSignature(fn *ast.FuncDecl, want string) bool {
+	if fn.Type.Results != nil && len(fn.Type.Results.List) > 0 {
+		return false
+	}
+	if fn.Type.Params == nil || len(fn.Type.Params.List) != 1 {
+		return false
+	}
+	param := fn.Type.Params.List[0]
+	return len(param.Names) <= 1 && typeString(param.Type) == want
+}
+
+// isNiladic reports whether fn takes no parameters and returns nothing.
+func isNiladic(fn *ast.FuncDecl) bool {
+	noParams := fn.Type.Params == nil || len(fn.Type.Params.List) == 0
+	noResults := fn.Type.Results == nil || len(fn.Type.Results.List) == 0
+	return noParams && noResults
+}
+
+// allowedFuzzTypes are the seed-corpus types the stdlib fuzzing engine
+// accepts as f.Fuzz callback parameters, beyond the leading *testing.T.
+var allowedFuzzTypes = map[string]bool{
+	"string":  true,
+	"bool":    true,
+	"float32": true,
+	"float64": true,
+	"int":     true,
+	"int8":    true,
+	"int16":   true,
+	"int32":   true,
+	"int64":   true,
+	"uint":    true,
+	"uint8":   true,
+	"uint16":  true,
+	"uint32":  true,
+	"uint64":  true,
+	"[]byte":  true,
+}
+
+// checkFuzzCallbacks reports f.Fuzz calls inside a FuzzXxx function
+// whose callback uses a parameter type outside allowedFuzzTypes.
+func checkFuzzCallbacks(fset *token.FileSet, fn *ast.FuncDecl) []Issue {
+	if fn.Body == nil {
+		return nil
+	}
+	var issues []Issue
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || sel.Sel.Name != "Fuzz" || len(call.Args) != 1 {
+			return true
+		}
+		lit, ok := call.Args[0].(*ast.FuncLit)
+		if !ok || lit.Type.Params == nil {
+			return true
+		}
+		for i, field := range lit.Type.Params.List {
+			if i == 0 {
+				continue // *testing.T
+			}
+			typ := typeString(field.Type)
+			if allowedFuzzTypes[typ] {
+				continue
+			}
+			issues = append(issues, Issue{
+				Pos:      fset.Position(field.Pos()),
+				Category: "Fuzz",
+				Message:  fmt.Sprintf("f.Fuzz callback parameter has disallowed type %s", typ),
+			})
+		}
+		return true
+	})
+	return issues
+}
+
+// typeString renders the small subset of type expressions that appear
+// in test function signatures ("*testing.T", "[]byte", "int", ...) as
+// their source form.
+func typeString(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		return "*" + typeString(e.X)
+	case *ast.SelectorExpr:
+		return typeString(e.X) + "." + e.Sel.Name
+	case *ast.Ident:
+		return e.Name
+	case *ast.ArrayType:
+		if e.Len == nil {
+			return "[]" + typeString(e.Elt)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,126 @@
+package check_test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/goaux/istest/check"
+)
+
+func parse(t *testing.T, src string) (*token.FileSet, []*ast.File) {
+	t.Helper()
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "example_test.go", src, 0)
+	if err != nil {
+		t.Fatalf("parser.ParseFile: %v", err)
+	}
+	return fset, []*ast.File{file}
+}
+
+func TestCheckFiles_valid(t *testing.T) {
+	const src = `package example
+
+import "testing"
+
+func TestFoo(t *testing.T) {}
+func BenchmarkFoo(b *testing.B) {}
+func ExampleFoo() {}
+func FuzzFoo(f *testing.F) {
+	f.Fuzz(func(t *testing.T, s string, n int) {})
+}
+func TestMain(m *testing.M) {}
+`
+	fset, files := parse(t, src)
+	issues := check.CheckFiles(fset, files)
+	if len(issues) != 0 {
+		t.Errorf("CheckFiles() = %v; want no issues", issues)
+	}
+}
+
+func TestCheckFiles_malformedName(t *testing.T) {
+	const src = `package example
+
+import "testing"
+
+func Testfoo(t *testing.T) {}
+`
+	fset, files := parse(t, src)
+	issues := check.CheckFiles(fset, files)
+	if len(issues) != 1 {
+		t.Fatalf("CheckFiles() = %v; want exactly one issue", issues)
+	}
+	if !strings.Contains(issues[0].Message, "malformed name") {
+		t.Errorf("issue message = %q; want it to mention a malformed name", issues[0].Message)
+	}
+}
+
+func TestCheckFiles_wrongSignature(t *testing.T) {
+	const src = `package example
+
+func TestFoo(t int) {}
+`
+	fset, files := parse(t, src)
+	issues := check.CheckFiles(fset, files)
+	if len(issues) != 1 {
+		t.Fatalf("CheckFiles() = %v; want exactly one issue", issues)
+	}
+	if !strings.Contains(issues[0].Message, "wrong signature") {
+		t.Errorf("issue message = %q; want it to mention a wrong signature", issues[0].Message)
+	}
+}
+
+func TestCheckFiles_disallowedFuzzType(t *testing.T) {
+	const src = `package example
+
+import "testing"
+
+func FuzzFoo(f *testing.F) {
+	f.Fuzz(func(t *testing.T, v map[string]int) {})
+}
+`
+	fset, files := parse(t, src)
+	issues := check.CheckFiles(fset, files)
+	if len(issues) != 1 {
+		t.Fatalf("CheckFiles() = %v; want exactly one issue", issues)
+	}
+	if !strings.Contains(issues[0].Message, "disallowed type") {
+		t.Errorf("issue message = %q; want it to mention a disallowed type", issues[0].Message)
+	}
+}
+
+func TestCheckFiles_testMainHelperIsRegularTest(t *testing.T) {
+	const src = `package example
+
+func TestMainHelper(t int) {}
+`
+	fset, files := parse(t, src)
+	issues := check.CheckFiles(fset, files)
+	if len(issues) != 1 {
+		t.Fatalf("CheckFiles() = %v; want exactly one issue", issues)
+	}
+	if issues[0].Category != "Test" {
+		t.Errorf("issue category = %q; want %q", issues[0].Category, "Test")
+	}
+}
+
+func TestCheckDir(t *testing.T) {
+	dir := t.TempDir()
+	const src = `package example
+
+func Testfoo(t int) {}
+`
+	if err := os.WriteFile(dir+"/example_test.go", []byte(src), 0o644); err != nil {
+		t.Fatalf("os.WriteFile: %v", err)
+	}
+	issues, err := check.CheckDir(dir)
+	if err != nil {
+		t.Fatalf("CheckDir: %v", err)
+	}
+	if len(issues) != 1 {
+		t.Fatalf("CheckDir() = %v; want exactly one issue", issues)
+	}
+}
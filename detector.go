@@ -0,0 +1,94 @@
+package istest
+
+import (
+	"os"
+	"runtime"
+	"strings"
+)
+
+// Detector determines whether the current process is running as part of
+// a Go test.
+type Detector interface {
+	IsTest() bool
+}
+
+// DetectorFunc adapts a plain function to a [Detector].
+type DetectorFunc func() bool
+
+// IsTest calls f.
+func (f DetectorFunc) IsTest() bool { return f() }
+
+// Detectors runs each of its elements in order and reports true as soon
+// as one of them does.
+type Detectors []Detector
+
+// IsTest reports true if any Detector in ds does.
+func (ds Detectors) IsTest() bool {
+	for _, d := range ds {
+		if d.IsTest() {
+			return true
+		}
+	}
+	return false
+}
+
+// ArgsSuffixDetector reports true if os.Args[0] has the ".test" suffix,
+// the name "go test" gives the compiled test binary by default. It
+// misses binaries built with "go test -o" under a different name.
+var ArgsSuffixDetector Detector = DetectorFunc(func() bool {
+	return len(os.Args) > 0 && strings.HasSuffix(os.Args[0], ".test")
+})
+
+// ArgsFlagDetector reports true if any argument in os.Args looks like a
+// "-test." flag, which the "go test" wrapper always passes to the test
+// binary regardless of what it is named.
+var ArgsFlagDetector Detector = DetectorFunc(func() bool {
+	for _, arg := range os.Args[1:] {
+		if strings.HasPrefix(arg, "-test.") || strings.HasPrefix(arg, "--test.") {
+			return true
+		}
+	}
+	return false
+})
+
+// StackDetector reports true if any frame of the current goroutine's
+// call stack is defined in a file with a "_test.go" suffix.
+var StackDetector Detector = DetectorFunc(func() bool {
+	pc := make([]uintptr, 64)
+	n := runtime.Callers(2, pc)
+	if n == 0 {
+		return false
+	}
+	iter := runtime.CallersFrames(pc[:n])
+	for {
+		frame, more := iter.Next()
+		if strings.HasSuffix(frame.File, "_test.go") {
+			return true
+		}
+		if !more {
+			break
+		}
+	}
+	return false
+})
+
+// Default is the composite [Detector] used by [Is]. It
+// combines every built-in strategy: [testing.Testing] (on Go 1.21 and
+// later, see detector_go121.go), the ".test" binary suffix, "-test."
+// flags in os.Args, and a "_test.go" frame anywhere on the call stack.
+var Default = defaultDetector()
+
+// currentDetector is the [Detector] consulted by isTestNow. It starts
+// as [Default] and can be swapped with [SetDetector].
+var currentDetector = Default
+
+// SetDetector replaces the [Detector] that [Is] uses to decide whether
+// the current process is running as a test; [IsContext] only reads the
+// value stored in its context and does not consult it. It exists so
+// tests of code depending on istest can force detection on or off; it
+// is not safe for concurrent use with [Is].
+func SetDetector(d Detector) { currentDetector = d }
+
+// isTestNow reports whether the current process is running as a Go
+// test, according to currentDetector.
+func isTestNow() bool { return currentDetector.IsTest() }
@@ -0,0 +1,15 @@
+//go:build go1.21
+
+package istest
+
+import "testing"
+
+// testingTestingDetector reports true if [testing.Testing] does.
+var testingTestingDetector Detector = DetectorFunc(testing.Testing)
+
+// defaultDetector builds [Default] on Go 1.21 and later, where
+// [testing.Testing] is available and takes priority over the other
+// strategies.
+func defaultDetector() Detector {
+	return Detectors{testingTestingDetector, ArgsSuffixDetector, ArgsFlagDetector, StackDetector}
+}
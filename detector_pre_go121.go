@@ -0,0 +1,9 @@
+//go:build !go1.21
+
+package istest
+
+// defaultDetector builds [Default] on Go versions before 1.21, where
+// [testing.Testing] does not exist yet.
+func defaultDetector() Detector {
+	return Detectors{ArgsSuffixDetector, ArgsFlagDetector, StackDetector}
+}
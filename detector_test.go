@@ -0,0 +1,71 @@
+package istest_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/goaux/istest"
+)
+
+func TestIs_renamedTestBinary(t *testing.T) {
+	dir := t.TempDir()
+	bin := filepath.Join(dir, "renamed-binary")
+	if runtime.GOOS == "windows" {
+		bin += ".exe"
+	}
+
+	build := exec.Command("go", "test", "-c", "-o", bin, ".")
+	build.Stderr = os.Stderr
+	if err := build.Run(); err != nil {
+		t.Fatalf("go test -c -o %s .: %v", bin, err)
+	}
+
+	run := exec.Command(bin, "-test.run", "^TestRenamedBinaryDetectsItself$", "-test.v")
+	out, err := run.CombinedOutput()
+	if err != nil {
+		t.Fatalf("running renamed test binary failed: %v\n%s", err, out)
+	}
+}
+
+func TestSetDetector(t *testing.T) {
+	t.Cleanup(func() { istest.SetDetector(istest.Default) })
+
+	istest.SetDetector(istest.DetectorFunc(func() bool { return false }))
+	if istest.Is() {
+		t.Fatal("istest.Is() should return false after SetDetector(false)")
+	}
+
+	istest.SetDetector(istest.DetectorFunc(func() bool { return true }))
+	if !istest.Is() {
+		t.Fatal("istest.Is() should return true after SetDetector(true)")
+	}
+}
+
+func TestDetectors(t *testing.T) {
+	no := istest.DetectorFunc(func() bool { return false })
+	yes := istest.DetectorFunc(func() bool { return true })
+
+	if (istest.Detectors{no, no}.IsTest()) {
+		t.Error("Detectors{no, no}.IsTest() should be false")
+	}
+	if !(istest.Detectors{no, yes}.IsTest()) {
+		t.Error("Detectors{no, yes}.IsTest() should be true")
+	}
+}
+
+// TestRenamedBinaryDetectsItself is the assertion run, via
+// TestIs_renamedTestBinary, from inside a test binary that was built
+// with "go test -c -o" under a name not ending in ".test". It only
+// exists to prove detection still works in that case; do not run it
+// directly as part of the normal package test suite's assumptions.
+func TestRenamedBinaryDetectsItself(t *testing.T) {
+	if !istest.Is() {
+		t.Fatal("istest.Is() should return true even when the binary is not named *.test")
+	}
+	if !istest.Is(istest.FuncTest) {
+		t.Fatal("istest.Is(istest.FuncTest) should return true even when the binary is not named *.test")
+	}
+}
@@ -0,0 +1,140 @@
+package istest
+
+import (
+	"context"
+	"os"
+	"strings"
+)
+
+// FuzzMode distinguishes the ways a FuzzXxx function can be executing.
+type FuzzMode int
+
+const (
+	// FuzzModeNone means the current execution is not a fuzz test at all.
+	FuzzModeNone FuzzMode = iota
+	// FuzzModeSeed means a FuzzXxx function is replaying its seed corpus
+	// (and any cached failing inputs) as part of a normal "go test" run.
+	FuzzModeSeed
+	// FuzzModeActive means a FuzzXxx function is actively generating
+	// inputs because "go test" was run with "-fuzz=".
+	FuzzModeActive
+)
+
+// String returns a string representation of the fuzz mode.
+func (m FuzzMode) String() string {
+	switch m {
+	case FuzzModeSeed:
+		return "FuzzModeSeed"
+	case FuzzModeActive:
+		return "FuzzModeActive"
+	default:
+		return "FuzzModeNone"
+	}
+}
+
+// modeOption is the [Option] returned by [Mode].
+type modeOption struct{ modes []FuzzMode }
+
+func (modeOption) isOption() {}
+
+// Mode returns an [Option] that restricts [Is] to executions where
+// [CurrentFuzzMode] currently returns one of the given modes.
+func Mode(modes ...FuzzMode) Option {
+	return modeOption{modes: modes}
+}
+
+// CurrentFuzzMode reports which fuzzing mode the current execution is in.
+//
+// It returns FuzzModeActive if a FuzzXxx function is on the call stack
+// and "go test" was invoked with "-test.fuzz=<pat>" (the flag the "go
+// test" wrapper forwards for "-fuzz="), FuzzModeSeed if a FuzzXxx
+// function is on the call stack but that flag is absent or empty, and
+// FuzzModeNone otherwise.
+func CurrentFuzzMode() FuzzMode {
+	if !isTestNow() {
+		return FuzzModeNone
+	}
+	if !Is(FuncFuzz) {
+		return FuzzModeNone
+	}
+	if pattern, ok := fuzzFlagPattern(os.Args[1:]); ok && pattern != "" {
+		return FuzzModeActive
+	}
+	return FuzzModeSeed
+}
+
+// fuzzFlagPattern looks for "-test.fuzz=<pat>", "--test.fuzz=<pat>", or
+// "-test.fuzz <pat>" (and its "--" variant) among args, returning the
+// pattern and whether the flag was present at all.
+func fuzzFlagPattern(args []string) (pattern string, ok bool) {
+	for i, arg := range args {
+		for _, prefix := range []string{"-test.fuzz=", "--test.fuzz="} {
+			if strings.HasPrefix(arg, prefix) {
+				return strings.TrimPrefix(arg, prefix), true
+			}
+		}
+		if arg == "-test.fuzz" || arg == "--test.fuzz" {
+			if i+1 < len(args) {
+				return args[i+1], true
+			}
+			return "", true
+		}
+	}
+	return "", false
+}
+
+type fuzzModeKey struct{}
+
+// FuzzModeContext creates a new context with the specified fuzz mode.
+//
+// Parameters:
+//   - parent: The parent context.
+//   - mode: The fuzz mode to store in the context.
+//
+// Returns:
+//   - context.Context: A new context containing the specified fuzz mode.
+//
+// See [IsFuzzModeContext].
+func FuzzModeContext(parent context.Context, mode FuzzMode) context.Context {
+	return context.WithValue(parent, fuzzModeKey{}, mode)
+}
+
+// IsFuzzModeContext checks if the context contains any of the specified fuzz modes.
+//
+// Parameters:
+//   - ctx: The context to check.
+//   - modes: Optional variadic parameter specifying the fuzz modes to check for.
+//
+// Returns:
+//   - bool: True if the context contains any of the specified fuzz modes (or any fuzz mode if none specified), false otherwise.
+//
+// See [FuzzModeContext].
+func IsFuzzModeContext(ctx context.Context, modes ...FuzzMode) bool {
+	mode, ok := ctx.Value(fuzzModeKey{}).(FuzzMode)
+	if !ok {
+		return false
+	}
+	if len(modes) == 0 {
+		return true
+	}
+	for _, m := range modes {
+		if m == mode {
+			return true
+		}
+	}
+	return false
+}
+
+// FuzzModeBackground returns the result of calling [FuzzModeContext]([context.Background](), mode).
+//
+// See [FuzzModeTODO].
+func FuzzModeBackground(mode FuzzMode) context.Context {
+	return FuzzModeContext(context.Background(), mode)
+}
+
+// FuzzModeTODO returns the result of calling [FuzzModeContext]([context.TODO](), mode).
+//
+// See [FuzzModeBackground].
+func FuzzModeTODO(mode FuzzMode) context.Context {
+	return FuzzModeContext(context.TODO(), mode)
+}
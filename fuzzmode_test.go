@@ -0,0 +1,93 @@
+package istest_test
+
+import (
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/goaux/istest"
+)
+
+func TestFuzzMode_outsideFuzz(t *testing.T) {
+	if mode := istest.CurrentFuzzMode(); mode != istest.FuzzModeNone {
+		t.Errorf("istest.CurrentFuzzMode() = %v; want %v", mode, istest.FuzzModeNone)
+	}
+}
+
+func FuzzMode_seed(f *testing.F) {
+	f.Fuzz(func(t *testing.T, _ int) {
+		if mode := istest.CurrentFuzzMode(); mode != istest.FuzzModeSeed {
+			t.Fatalf("istest.CurrentFuzzMode() = %v; want %v", mode, istest.FuzzModeSeed)
+		}
+		if !istest.Is(istest.Mode(istest.FuzzModeSeed)) {
+			t.Fatal("istest.Is(istest.Mode(istest.FuzzModeSeed)) returns false; want true")
+		}
+		if istest.Is(istest.Mode(istest.FuzzModeActive)) {
+			t.Fatal("istest.Is(istest.Mode(istest.FuzzModeActive)) returns true; want false")
+		}
+	})
+}
+
+// fuzzModeActiveEnv, when set to "1" in FuzzMode_active's environment,
+// tells it to require FuzzModeActive instead of merely observing
+// whatever mode the current run happens to be in. This lets
+// FuzzMode_active's seed corpus replay harmlessly during a plain
+// "go test ./..." while TestCurrentFuzzMode_active can still demand
+// FuzzModeActive from a real "-fuzz=" run.
+const fuzzModeActiveEnv = "ISTEST_REQUIRE_ACTIVE"
+
+func FuzzMode_active(f *testing.F) {
+	f.Fuzz(func(t *testing.T, _ int) {
+		mode := istest.CurrentFuzzMode()
+		if os.Getenv(fuzzModeActiveEnv) != "1" {
+			return
+		}
+		if mode != istest.FuzzModeActive {
+			t.Fatalf("istest.CurrentFuzzMode() = %v; want %v", mode, istest.FuzzModeActive)
+		}
+		if !istest.Is(istest.Mode(istest.FuzzModeActive)) {
+			t.Fatal("istest.Is(istest.Mode(istest.FuzzModeActive)) returns false; want true")
+		}
+		if istest.Is(istest.Mode(istest.FuzzModeSeed)) {
+			t.Fatal("istest.Is(istest.Mode(istest.FuzzModeSeed)) returns true; want false")
+		}
+	})
+}
+
+// TestCurrentFuzzMode_active runs FuzzMode_active in a subprocess with
+// "-fuzz=" set, the way "go test" forwards "-test.fuzz=" to actively
+// generate inputs, and requires it to observe FuzzModeActive. This
+// guards fuzzFlagPattern's os.Args parsing against regressions that
+// FuzzMode_seed's plain (non-fuzzing) run can't catch.
+func TestCurrentFuzzMode_active(t *testing.T) {
+	cmd := exec.Command("go", "test", "-run=^$", "-fuzz=^FuzzMode_active$", "-fuzztime=1x", ".")
+	cmd.Env = append(os.Environ(), fuzzModeActiveEnv+"=1")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("go test -fuzz=^FuzzMode_active$ failed: %v\n%s", err, out)
+	}
+}
+
+func TestFuzzModeContext(t *testing.T) {
+	ctx := istest.FuzzModeBackground(istest.FuzzModeActive)
+
+	if !istest.IsFuzzModeContext(ctx) {
+		t.Fatal("istest.IsFuzzModeContext(ctx) returns false; want true")
+	}
+
+	if !istest.IsFuzzModeContext(ctx, istest.FuzzModeActive) {
+		t.Fatal("istest.IsFuzzModeContext(ctx, istest.FuzzModeActive) returns false; want true")
+	}
+
+	if istest.IsFuzzModeContext(ctx, istest.FuzzModeSeed) {
+		t.Fatal("istest.IsFuzzModeContext(ctx, istest.FuzzModeSeed) returns true; want false")
+	}
+}
+
+func TestFuzzModeTODO(t *testing.T) {
+	ctx := istest.FuzzModeTODO(istest.FuzzModeNone)
+
+	if !istest.IsFuzzModeContext(ctx, istest.FuzzModeNone) {
+		t.Fatal("istest.IsFuzzModeContext(ctx, istest.FuzzModeNone) returns false; want true")
+	}
+}
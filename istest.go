@@ -2,9 +2,12 @@
 
 // Use with care as excessive use can ruin your tests.
 //
-// The determination is made based on whether `os.Args[0]` ends with ".test".
+// The determination is made by [Default], a layered [Detector] that combines
+// [testing.Testing], whether `os.Args[0]` ends with ".test", the presence of
+// a "-test." flag in os.Args, and a "_test.go" frame anywhere on the call
+// stack. See [SetDetector] to override this in tests of your own.
 //
-// The determination of which of the four test functions, namely TestXxx, ExampleXxx, BenchmarkXxx and FuzzXxx, is based on the function name in the call stack and the file name ending with "_test.go".
+// The determination of which of the test functions, namely TestXxx, ExampleXxx, BenchmarkXxx, FuzzXxx and TestMain, is based on the function name in the call stack and the file name ending with "_test.go".
 //
 // Alternatively, you can set it explicitly in the context instead of determining it automatically.
 //
@@ -32,11 +35,15 @@
 //	if istest.IsContext(ctx, istest.FuncTest) {
 //	    // Execute test-specific code
 //	}
+//
+//	// Check whether a fuzz test is actively generating inputs
+//	if istest.Is(istest.FuncFuzz, istest.Mode(istest.FuzzModeActive)) {
+//	    // Skip expensive stubs during real fuzzing
+//	}
 package istest
 
 import (
 	"context"
-	"os"
 	"runtime"
 	"strings"
 
@@ -47,7 +54,18 @@ import (
 type function string
 
 // Match returns true if the name starts with the function prefix, false otherwise.
-func (f function) Match(name string) bool { return strings.HasPrefix(name, string(f)) }
+//
+// FuncTestMain is special-cased: it matches only the exact name "TestMain",
+// and FuncTest excludes that same exact name so the two never both match.
+func (f function) Match(name string) bool {
+	if f == FuncTestMain {
+		return name == "TestMain"
+	}
+	if f == FuncTest && name == "TestMain" {
+		return false
+	}
+	return strings.HasPrefix(name, string(f))
+}
 
 // String returns a string representation of the function type.
 func (f function) String() string { return "Func" + string(f) }
@@ -57,6 +75,7 @@ var (
 	FuncFuzz      = function("Fuzz")      // FuncFuzz represents a fuzz test function.
 	FuncExample   = function("Example")   // FuncExample represents an example function.
 	FuncTest      = function("Test")      // FuncTest represents a test function.
+	FuncTestMain  = function("TestMain")  // FuncTestMain represents the TestMain(m *testing.M) function.
 )
 
 // Functions returns a slice of specified function types.
@@ -64,27 +83,66 @@ func Functions(functions ...function) []function {
 	return functions
 }
 
-// isTest is true if os.Args[0] has the suffix ".test".
-var isTest = len(os.Args) > 0 && strings.HasSuffix(os.Args[0], ".test")
+// Option is implemented by [function] and by the [Option] returned from
+// [Mode], and configures what [Is] matches against.
+type Option interface {
+	isOption()
+}
+
+func (function) isOption() {}
 
 // Is determines if the current execution context is within a test environment.
 //
 // It returns true if any of the following conditions are met:
-// 1. The program is running as a test (os.Args[0] ends with ".test") and no specific test functions are specified.
+// 1. The program is running as a test, per [Default] (or whatever [Detector] was set with [SetDetector]), and no specific test functions are specified.
 // 2. The program is running as a test and there's a function in the call stack that:
 //   - Has a name prefixed with any of the provided function types
 //   - Is defined in a file with a "_test.go" suffix
 //
+// Passing one or more [Mode] options additionally requires [CurrentFuzzMode] to
+// currently return one of the given modes; this is independent of, and
+// combined with (AND), any function types also passed.
+//
 // Parameters:
-//   - functions: Optional variadic parameter specifying the types of tests to check for.
-//     Valid values are FuncTest, FuncExample, FuncBenchmark, and FuncFuzz.
+//   - options: Optional variadic parameter specifying the types of tests,
+//     and/or fuzz modes, to check for. Valid function values are FuncTest,
+//     FuncExample, FuncBenchmark, FuncFuzz, and FuncTestMain.
 //
 // Returns:
 //   - bool: True if the execution is within a test environment matching the specified criteria, false otherwise.
-func Is(functions ...function) bool {
-	if !isTest {
+func Is(options ...Option) bool {
+	if !isTestNow() {
 		return false
 	}
+	if len(options) == 0 {
+		return true
+	}
+
+	var functions []function
+	var modes []FuzzMode
+	for _, opt := range options {
+		switch o := opt.(type) {
+		case function:
+			functions = append(functions, o)
+		case modeOption:
+			modes = append(modes, o.modes...)
+		}
+	}
+
+	if len(modes) > 0 {
+		current := CurrentFuzzMode()
+		var ok bool
+		for _, m := range modes {
+			if m == current {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return false
+		}
+	}
+
 	if len(functions) == 0 {
 		return true
 	}
@@ -111,6 +169,56 @@ func Is(functions ...function) bool {
 	return false
 }
 
+// categories lists every function type Name searches for, in the order
+// they are tried. FuncTestMain is checked ahead of FuncTest so that an
+// exact "TestMain" match is reported as FuncTestMain rather than falling
+// through.
+var categories = []function{FuncTestMain, FuncTest, FuncExample, FuncBenchmark, FuncFuzz}
+
+// Name returns the name of the test function currently executing on the
+// call stack, along with the [function] category that produced it.
+//
+// Name scans frames starting from its caller outward for the first
+// "_test.go" frame whose unqualified function name matches one of
+// FuncTestMain, FuncTest, FuncExample, FuncBenchmark or FuncFuzz, and
+// reports that name with any closures rendered as a slash-joined path
+// (e.g. "TestFoo/func1").
+//
+// That path is derived from the Go runtime's closure naming, not from
+// [testing.T.Run]'s subtest names: t.Run runs its callback in its own
+// goroutine, so the call stack Name walks does not retain the parent
+// Test's frame or the string passed to t.Run. Called from inside
+// t.Run("sub_case", ...), full is "TestFoo/func1", not "TestFoo/sub_case".
+//
+// If Name is called from a non-test frame, or the process is not
+// detected as a test at all (see [Default]), ok is false.
+func Name() (full string, fn function, ok bool) {
+	if !isTestNow() {
+		return "", "", false
+	}
+	pc := make([]uintptr, 16)
+	n := runtime.Callers(2, pc)
+	if n == 0 {
+		return "", "", false
+	}
+	iter := runtime.CallersFrames(pc[:n])
+	for {
+		frame, more := iter.Next()
+		if strings.HasSuffix(frame.File, "_test.go") {
+			_, name := funcname.Split(frame.Function)
+			for _, f := range categories {
+				if f.Match(name) {
+					return strings.ReplaceAll(name, ".", "/"), f, true
+				}
+			}
+		}
+		if !more {
+			break
+		}
+	}
+	return "", "", false
+}
+
 type key struct{}
 
 // Context creates a new context with the specified function type.
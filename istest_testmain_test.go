@@ -0,0 +1,50 @@
+package istest_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/goaux/istest"
+)
+
+// TestMain verifies that istest.Is recognizes TestMain itself as
+// FuncTestMain, and that it is not also recognized as FuncTest.
+func TestMain(m *testing.M) {
+	if !istest.Is(istest.FuncTestMain) {
+		fmt.Fprintln(os.Stderr, "istest.Is(istest.FuncTestMain) should return true inside TestMain")
+		os.Exit(1)
+	}
+	if istest.Is(istest.FuncTest) {
+		fmt.Fprintln(os.Stderr, "istest.Is(istest.FuncTest) should return false inside TestMain")
+		os.Exit(1)
+	}
+	os.Exit(m.Run())
+}
+
+func TestFuncTestMainMatch(t *testing.T) {
+	if !istest.FuncTestMain.Match("TestMain") {
+		t.Error(`FuncTestMain.Match("TestMain") should return true`)
+	}
+	if istest.FuncTestMain.Match("TestMainHelper") {
+		t.Error(`FuncTestMain.Match("TestMainHelper") should return false`)
+	}
+	if istest.FuncTest.Match("TestMain") {
+		t.Error(`FuncTest.Match("TestMain") should return false`)
+	}
+	if !istest.FuncTest.Match("TestMainHelper") {
+		t.Error(`FuncTest.Match("TestMainHelper") should return true`)
+	}
+}
+
+func TestBackgroundTestMain(t *testing.T) {
+	ctx := istest.Background(istest.FuncTestMain)
+
+	if !istest.IsContext(ctx, istest.FuncTestMain) {
+		t.Fatal("istest.IsContext(ctx, istest.FuncTestMain) returns false; want true")
+	}
+
+	if istest.IsContext(ctx, istest.FuncTest) {
+		t.Fatal("istest.IsContext(ctx, istest.FuncTest) returns true; want false")
+	}
+}
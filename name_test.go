@@ -0,0 +1,50 @@
+package istest_test
+
+import (
+	"testing"
+
+	"github.com/goaux/istest"
+)
+
+func TestName(t *testing.T) {
+	full, fn, ok := istest.Name()
+	if !ok {
+		t.Fatal("istest.Name() returns ok=false; want true")
+	}
+	if fn != istest.FuncTest {
+		t.Errorf("istest.Name() fn = %v; want %v", fn, istest.FuncTest)
+	}
+	if full != "TestName" {
+		t.Errorf("istest.Name() full = %q; want %q", full, "TestName")
+	}
+}
+
+// TestName_subtest documents that, from inside a t.Run subtest, Name
+// reports the runtime name of the subtest's closure (e.g.
+// "TestName_subtest/func1"), not the "TestName_subtest/sub_case" path
+// t.Name() would report: t.Run runs the closure in its own goroutine,
+// so the parent Test's frame - and the "sub_case" name given to t.Run -
+// are not on the call stack Name can see.
+func TestName_subtest(t *testing.T) {
+	t.Run("sub_case", func(t *testing.T) {
+		full, fn, ok := istest.Name()
+		if !ok {
+			t.Fatal("istest.Name() returns ok=false; want true")
+		}
+		if fn != istest.FuncTest {
+			t.Errorf("istest.Name() fn = %v; want %v", fn, istest.FuncTest)
+		}
+		const want = "TestName_subtest/func1"
+		if full != want {
+			t.Errorf("istest.Name() full = %q; want %q", full, want)
+		}
+	})
+}
+
+func ExampleName() {
+	full, fn, ok := istest.Name()
+	if !ok || fn != istest.FuncExample || full != "ExampleName" {
+		panic("istest.Name() did not identify ExampleName")
+	}
+	// Output:
+}